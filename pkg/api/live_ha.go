@@ -0,0 +1,38 @@
+package api
+
+import (
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+// HTTPServer is Grafana's HTTP server. Only the fields/methods touched by
+// packages in this checkout are declared here; the rest of the server's
+// route registration lives on the same struct.
+type HTTPServer struct {
+	RouteRegister routing.RouteRegister
+
+	// LiveHAManager is the leader-election Manager built by
+	// leader.NewFromSettingCfg for Grafana Live HA, or nil when Live HA
+	// hasn't been wired up. registerLiveHARoutes is a no-op when it's nil.
+	LiveHAManager leader.Manager
+}
+
+// NewHTTPServer builds an HTTPServer and registers its routes, including
+// the Live HA admin endpoints when liveHAManager is non-nil.
+func NewHTTPServer(routeRegister routing.RouteRegister, liveHAManager leader.Manager) *HTTPServer {
+	hs := &HTTPServer{
+		RouteRegister: routeRegister,
+		LiveHAManager: liveHAManager,
+	}
+	hs.registerLiveHARoutes()
+	return hs
+}
+
+// registerLiveHARoutes mounts the Live HA admin endpoints, e.g. the
+// leader-election health check, onto hs.RouteRegister.
+func (hs *HTTPServer) registerLiveHARoutes() {
+	if hs.LiveHAManager == nil {
+		return
+	}
+	leader.RegisterHealthRoute(hs.RouteRegister, hs.LiveHAManager)
+}
@@ -0,0 +1,80 @@
+package featuremgmt
+
+import (
+	"context"
+	"sync/atomic"
+	"time"
+)
+
+// Entitlements describes which license entitlements are currently granted,
+// e.g. by the loaded Grafana Enterprise license. A zero Entitlements grants
+// nothing, matching an unlicensed OSS install.
+type Entitlements struct {
+	// Granted is the set of entitlement names the current license grants,
+	// e.g. "enterprise" or "enterprise.reporting".
+	Granted map[string]bool
+}
+
+// Has reports whether name is granted. An empty name is always granted,
+// matching FeatureFlag.RequiresLicense's "no license required" zero value.
+func (e *Entitlements) Has(name string) bool {
+	if name == "" {
+		return true
+	}
+	if e == nil {
+		return false
+	}
+	return e.Granted[name]
+}
+
+// EntitlementsSource produces the current Entitlements, e.g. by reading the
+// loaded license. Implementations are expected to be cheap enough to call on
+// the refresh interval passed to NewManager.
+type EntitlementsSource interface {
+	Entitlements(ctx context.Context) (*Entitlements, error)
+}
+
+// entitlementsStore holds the current Entitlements behind an atomic pointer
+// so IsEnabled can read it on its hot path without taking a lock.
+type entitlementsStore struct {
+	current atomic.Value // *Entitlements
+}
+
+func newEntitlementsStore() *entitlementsStore {
+	s := &entitlementsStore{}
+	s.current.Store(&Entitlements{})
+	return s
+}
+
+func (s *entitlementsStore) get() *Entitlements {
+	return s.current.Load().(*Entitlements)
+}
+
+func (s *entitlementsStore) set(e *Entitlements) {
+	if e == nil {
+		e = &Entitlements{}
+	}
+	s.current.Store(e)
+}
+
+// refreshFrom polls source on interval until ctx is done, atomically
+// swapping in whatever Entitlements it returns. A failed poll is logged and
+// otherwise ignored - entitlements stay at their last known-good value
+// rather than being stripped by a transient error.
+func (s *entitlementsStore) refreshFrom(ctx context.Context, source EntitlementsSource, interval time.Duration) {
+	ticker := time.NewTicker(interval)
+	defer ticker.Stop()
+	for {
+		select {
+		case <-ctx.Done():
+			return
+		case <-ticker.C:
+			e, err := source.Entitlements(ctx)
+			if err != nil {
+				logger.Warn("failed to refresh license entitlements, keeping previous value", "error", err)
+				continue
+			}
+			s.set(e)
+		}
+	}
+}
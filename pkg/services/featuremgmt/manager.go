@@ -0,0 +1,112 @@
+package featuremgmt
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+)
+
+var logger = log.New("featuremgmt")
+
+// DefaultEntitlementsRefreshInterval is how often NewManager polls an
+// EntitlementsSource for changes when one is configured.
+const DefaultEntitlementsRefreshInterval = 1 * time.Hour
+
+// FeatureManager evaluates whether a flag is enabled, taking the configured
+// toggle state and (for license-gated flags) the currently loaded license's
+// Entitlements into account.
+type FeatureManager interface {
+	IsEnabled(flag string) bool
+	GetEnabled() map[string]bool
+	// Warnings lists flags that are turned on by configuration but gated off
+	// because the current license does not grant their RequiresLicense
+	// entitlement, so the UI can surface them to the operator.
+	Warnings() []string
+}
+
+type manager struct {
+	enabled      map[string]bool // flags turned on by config, regardless of license
+	flagsByName  map[string]FeatureFlag
+	entitlements *entitlementsStore
+
+	// warnOnce ensures the gated-by-license log line for a flag only fires
+	// once per process rather than on every IsEnabled call.
+	warnOnce sync.Map // flag name -> struct{}
+}
+
+// NewManager builds a FeatureManager for enabled (the flags turned on by
+// configuration) evaluated against flags (normally standardFeatureFlags).
+// source, if non-nil, is polled on refreshInterval (DefaultEntitlementsRefreshInterval
+// when zero or negative) to keep entitlements current; pass a nil source for
+// OSS installs, which grants nothing so every RequiresLicense flag stays off
+// regardless of its toggle.
+func NewManager(enabled map[string]bool, flags []FeatureFlag, source EntitlementsSource, refreshInterval time.Duration) FeatureManager {
+	if refreshInterval <= 0 {
+		refreshInterval = DefaultEntitlementsRefreshInterval
+	}
+	flagsByName := make(map[string]FeatureFlag, len(flags))
+	for _, f := range flags {
+		flagsByName[f.Name] = f
+	}
+
+	m := &manager{
+		enabled:      enabled,
+		flagsByName:  flagsByName,
+		entitlements: newEntitlementsStore(),
+	}
+
+	if source != nil {
+		if e, err := source.Entitlements(context.Background()); err == nil {
+			m.entitlements.set(e)
+		}
+		go m.entitlements.refreshFrom(context.Background(), source, refreshInterval)
+	}
+
+	return m
+}
+
+func (m *manager) IsEnabled(flag string) bool {
+	if !m.enabled[flag] {
+		return false
+	}
+	f, ok := m.flagsByName[flag]
+	if !ok || f.RequiresLicense == "" {
+		return true
+	}
+	if m.entitlements.get().Has(f.RequiresLicense) {
+		return true
+	}
+	if _, alreadyLogged := m.warnOnce.LoadOrStore(flag, struct{}{}); !alreadyLogged {
+		logger.Warn("feature flag requested but gated off by license", "flag", flag, "requiresLicense", f.RequiresLicense)
+	}
+	return false
+}
+
+func (m *manager) GetEnabled() map[string]bool {
+	result := make(map[string]bool, len(m.enabled))
+	for flag := range m.enabled {
+		if m.IsEnabled(flag) {
+			result[flag] = true
+		}
+	}
+	return result
+}
+
+func (m *manager) Warnings() []string {
+	var warnings []string
+	for flag, on := range m.enabled {
+		if !on {
+			continue
+		}
+		f, ok := m.flagsByName[flag]
+		if !ok || f.RequiresLicense == "" {
+			continue
+		}
+		if !m.entitlements.get().Has(f.RequiresLicense) {
+			warnings = append(warnings, flag)
+		}
+	}
+	return warnings
+}
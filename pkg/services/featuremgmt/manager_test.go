@@ -0,0 +1,87 @@
+package featuremgmt
+
+import (
+	"context"
+	"sync"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+var testFlags = []FeatureFlag{
+	{Name: "free-flag", Description: "no license required"},
+	{Name: "licensed-flag", Description: "requires enterprise", RequiresLicense: "enterprise"},
+}
+
+type staticEntitlementsSource struct {
+	mu    sync.Mutex
+	value *Entitlements
+	err   error
+}
+
+func (s *staticEntitlementsSource) Entitlements(ctx context.Context) (*Entitlements, error) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	return s.value, s.err
+}
+
+func (s *staticEntitlementsSource) set(e *Entitlements) {
+	s.mu.Lock()
+	defer s.mu.Unlock()
+	s.value = e
+}
+
+func TestManager_GatesOnLicense(t *testing.T) {
+	enabled := map[string]bool{"free-flag": true, "licensed-flag": true}
+
+	t.Run("unlicensed install gates licensed flags off", func(t *testing.T) {
+		m := NewManager(enabled, testFlags, nil, time.Hour)
+		require.True(t, m.IsEnabled("free-flag"))
+		require.False(t, m.IsEnabled("licensed-flag"))
+		require.Equal(t, []string{"licensed-flag"}, m.Warnings())
+	})
+
+	t.Run("entitlements from source are honored at startup", func(t *testing.T) {
+		source := &staticEntitlementsSource{value: &Entitlements{Granted: map[string]bool{"enterprise": true}}}
+		m := NewManager(enabled, testFlags, source, time.Hour)
+		require.True(t, m.IsEnabled("licensed-flag"))
+		require.Empty(t, m.Warnings())
+	})
+
+	t.Run("license downgrade flips a flag off mid-process", func(t *testing.T) {
+		source := &staticEntitlementsSource{value: &Entitlements{Granted: map[string]bool{"enterprise": true}}}
+		impl := NewManager(enabled, testFlags, source, time.Hour).(*manager)
+		require.True(t, impl.IsEnabled("licensed-flag"))
+
+		// Simulate the periodic refresh observing a downgraded/expired license.
+		impl.entitlements.set(&Entitlements{})
+		require.False(t, impl.IsEnabled("licensed-flag"))
+	})
+
+	t.Run("entitlements swap is safe for concurrent readers", func(t *testing.T) {
+		m := NewManager(enabled, testFlags, nil, time.Hour).(*manager)
+
+		var wg sync.WaitGroup
+		stop := make(chan struct{})
+		for i := 0; i < 8; i++ {
+			wg.Add(1)
+			go func() {
+				defer wg.Done()
+				for {
+					select {
+					case <-stop:
+						return
+					default:
+						_ = m.IsEnabled("licensed-flag")
+					}
+				}
+			}()
+		}
+		for i := 0; i < 100; i++ {
+			m.entitlements.set(&Entitlements{Granted: map[string]bool{"enterprise": i%2 == 0}})
+		}
+		close(stop)
+		wg.Wait()
+	})
+}
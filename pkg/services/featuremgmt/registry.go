@@ -0,0 +1,134 @@
+package featuremgmt
+
+// FeatureStage describes how far along a feature flag is on its way from
+// experimental to generally available (or removed).
+type FeatureStage string
+
+const (
+	StageExperimental FeatureStage = "experimental"
+	StageBeta         FeatureStage = "beta"
+	StageGA           FeatureStage = "ga"
+	StageDeprecated   FeatureStage = "deprecated"
+)
+
+// FeatureFlag defines a feature flag and its metadata.
+type FeatureFlag struct {
+	Name        string
+	Description string
+
+	// RequiresLicense names the license entitlement that must be granted,
+	// e.g. "enterprise" or "enterprise.reporting", for this flag to be
+	// enabled even when its toggle is on. Empty means the flag is gated by
+	// its toggle state alone.
+	RequiresLicense string
+
+	// Stage is where this flag is on the experimental -> beta -> ga ->
+	// deprecated lifecycle. Defaults to StageExperimental when unset.
+	Stage FeatureStage
+	// Owner is the team responsible for this flag, e.g. "grafana-app-platform-squad".
+	Owner string
+	// DeprecatedInVersion is the Grafana version that first marked this flag
+	// deprecated. Only meaningful once Stage is StageDeprecated.
+	DeprecatedInVersion string
+	// RemoveInVersion is the Grafana version that plans to remove this flag
+	// entirely. Required once Stage is StageDeprecated - see
+	// TestFeatureFlagMetadataIsHonest.
+	RemoveInVersion string
+	// RequiresRestart is true when toggling this flag only takes effect
+	// after a full Grafana server restart, e.g. because it's read once at
+	// startup to wire up a different code path.
+	RequiresRestart bool
+}
+
+// FeatureFlagInfo is the read-only metadata view of a FeatureFlag exposed by
+// AllFlags and Deprecated, e.g. for an admin UI to render stage badges and
+// deprecation warnings.
+type FeatureFlagInfo struct {
+	Name                string
+	Description         string
+	RequiresLicense     string
+	Stage               FeatureStage
+	Owner               string
+	DeprecatedInVersion string
+	RemoveInVersion     string
+	RequiresRestart     bool
+}
+
+func toFeatureFlagInfo(f FeatureFlag) FeatureFlagInfo {
+	stage := f.Stage
+	if stage == "" {
+		stage = StageExperimental
+	}
+	return FeatureFlagInfo{
+		Name:                f.Name,
+		Description:         f.Description,
+		RequiresLicense:     f.RequiresLicense,
+		Stage:               stage,
+		Owner:               f.Owner,
+		DeprecatedInVersion: f.DeprecatedInVersion,
+		RemoveInVersion:     f.RemoveInVersion,
+		RequiresRestart:     f.RequiresRestart,
+	}
+}
+
+// AllFlags returns metadata for every standard feature flag, e.g. for an
+// admin UI to render the full flag catalog with stage badges.
+func AllFlags() []FeatureFlagInfo {
+	infos := make([]FeatureFlagInfo, 0, len(standardFeatureFlags))
+	for _, f := range standardFeatureFlags {
+		infos = append(infos, toFeatureFlagInfo(f))
+	}
+	return infos
+}
+
+// Deprecated returns metadata for every standard feature flag whose Stage is
+// StageDeprecated, e.g. for an admin UI to surface deprecation warnings.
+func Deprecated() []FeatureFlagInfo {
+	var infos []FeatureFlagInfo
+	for _, f := range standardFeatureFlags {
+		if f.Stage == StageDeprecated {
+			infos = append(infos, toFeatureFlagInfo(f))
+		}
+	}
+	return infos
+}
+
+// standardFeatureFlags are the built-in feature toggles Grafana ships with.
+// Run `go test ./pkg/services/featuremgmt/...` after editing this list to
+// regenerate registry_gen.go and featureToggles.gen.ts.
+var standardFeatureFlags = []FeatureFlag{
+	{
+		Name:        "live-service-web-worker",
+		Description: "This will use a webworker thread to processes events rather than the main thread",
+		Stage:       StageExperimental,
+		Owner:       "grafana-app-platform-squad",
+	},
+	{
+		Name:        "database_metrics",
+		Description: "Register a distinct Prometheus metrics namespace for database access",
+		Stage:       StageGA,
+		Owner:       "grafana-backend-platform-squad",
+	},
+	{
+		Name:            "reporting",
+		Description:     "Scheduled exports of dashboards as PDF reports",
+		RequiresLicense: "enterprise.reporting",
+		Stage:           StageGA,
+		Owner:           "grafana-enterprise-squad",
+	},
+	{
+		Name:            "dataTrails",
+		Description:     "Enable the licensed data-lineage explorer",
+		RequiresLicense: "enterprise",
+		Stage:           StageBeta,
+		Owner:           "grafana-enterprise-squad",
+	},
+	{
+		Name:                "oldPanelInspector",
+		Description:         "The pre-9.0 panel inspector drawer, kept around during the migration period",
+		Stage:               StageDeprecated,
+		Owner:               "grafana-dataviz-squad",
+		DeprecatedInVersion: "9.0.0",
+		RemoveInVersion:     "10.0.0",
+	},
+}
@@ -0,0 +1,56 @@
+package featuremgmt
+
+type FeatureToggles struct {
+	manager FeatureManager
+}
+
+// IsEnabled checks if a flag is enabled
+func (ft *FeatureToggles) IsEnabled(flag string) bool {
+	return ft.manager.IsEnabled(flag)
+}
+
+// GetEnabled returns a map contaning only the features that are enabled
+func (ft *FeatureToggles) GetEnabled() map[string]bool {
+	return ft.manager.GetEnabled()
+}
+
+// Warnings lists flags that are turned on by configuration but gated off by
+// the current license, so the UI can surface them to the operator.
+func (ft *FeatureToggles) Warnings() []string {
+	return ft.manager.Warnings()
+}
+
+// LiveServiceWebWorker checks for the flag: live-service-web-worker
+// This will use a webworker thread to processes events rather than the main thread
+// Stage: experimental, Owner: grafana-app-platform-squad
+func (ft *FeatureToggles) IsLiveServiceWebWorkerEnabled() bool {
+	return ft.manager.IsEnabled("live-service-web-worker")
+}
+
+// DatabaseMetrics checks for the flag: database_metrics
+// Register a distinct Prometheus metrics namespace for database access
+// Stage: ga, Owner: grafana-backend-platform-squad
+func (ft *FeatureToggles) IsDatabaseMetricsEnabled() bool {
+	return ft.manager.IsEnabled("database_metrics")
+}
+
+// Reporting checks for the flag: reporting
+// Scheduled exports of dashboards as PDF reports
+// Stage: ga, Owner: grafana-enterprise-squad
+func (ft *FeatureToggles) IsReportingEnabled() bool {
+	return ft.manager.IsEnabled("reporting")
+}
+
+// DataTrails checks for the flag: dataTrails
+// Enable the licensed data-lineage explorer
+// Stage: beta, Owner: grafana-enterprise-squad
+func (ft *FeatureToggles) IsDataTrailsEnabled() bool {
+	return ft.manager.IsEnabled("dataTrails")
+}
+
+// OldPanelInspector checks for the flag: oldPanelInspector
+// The pre-9.0 panel inspector drawer, kept around during the migration period
+// Stage: deprecated, Owner: grafana-dataviz-squad, removed in 10.0.0
+func (ft *FeatureToggles) IsOldPanelInspectorEnabled() bool {
+	return ft.manager.IsEnabled("oldPanelInspector")
+}
@@ -50,6 +50,32 @@ export interface FeatureToggles {
 	}
 
 	buf += "}\n"
+
+	buf += `
+/**
+ * Metadata about each feature toggle, so the admin UI can render stage
+ * badges and deprecation warnings without a second API round-trip.
+ *
+ * @public
+ */
+export const featureTogglesMeta: Record<
+  keyof FeatureToggles,
+  { stage: 'experimental' | 'beta' | 'ga' | 'deprecated'; description: string; deprecatedIn?: string }
+> = {
+`
+	for _, flag := range standardFeatureFlags {
+		stage := flag.Stage
+		if stage == "" {
+			stage = StageExperimental
+		}
+		buf += fmt.Sprintf("  %s: { stage: '%s', description: %q", getTypeScriptKey(flag.Name), stage, flag.Description)
+		if flag.DeprecatedInVersion != "" {
+			buf += fmt.Sprintf(", deprecatedIn: %q", flag.DeprecatedInVersion)
+		}
+		buf += " },\n"
+	}
+	buf += "};\n"
+
 	return buf
 }
 
@@ -94,6 +120,7 @@ func generateRegistry() (string, error) {
 	tmpl, err := template.New("fn").Parse(`
 // {{.CamleCase}} checks for the flag: {{.Flag.Name}}
 // {{.Flag.Description}}
+// Stage: {{.Flag.Stage}}{{if .Flag.Owner}}, Owner: {{.Flag.Owner}}{{end}}{{if .Flag.RemoveInVersion}}, removed in {{.Flag.RemoveInVersion}}{{end}}
 func (ft *FeatureToggles) Is{{.CamleCase}}Enabled() bool {
 	return ft.manager.IsEnabled("{{.Flag.Name}}")
 }
@@ -126,9 +153,18 @@ func (ft *FeatureToggles) IsEnabled(flag string) bool {
 func (ft *FeatureToggles) GetEnabled() map[string]bool {
 	return ft.manager.GetEnabled()
 }
+
+// Warnings lists flags that are turned on by configuration but gated off by
+// the current license, so the UI can surface them to the operator.
+func (ft *FeatureToggles) Warnings() []string {
+	return ft.manager.Warnings()
+}
 `)
 
 	for _, flag := range standardFeatureFlags {
+		if flag.Stage == "" {
+			flag.Stage = StageExperimental
+		}
 		data.CamleCase = asCamelCase(flag.Name)
 		data.Flag = flag
 
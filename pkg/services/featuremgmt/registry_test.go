@@ -0,0 +1,20 @@
+package featuremgmt
+
+import (
+	"testing"
+)
+
+// TestFeatureFlagMetadataIsHonest keeps standardFeatureFlags' metadata
+// trustworthy as the list grows: a GA flag with no owner means nobody is on
+// the hook for it, and a deprecated flag with no removal version never
+// actually gets removed.
+func TestFeatureFlagMetadataIsHonest(t *testing.T) {
+	for _, flag := range standardFeatureFlags {
+		if flag.Stage == StageGA && flag.Owner == "" {
+			t.Errorf("flag %q is GA but has no Owner", flag.Name)
+		}
+		if flag.Stage == StageDeprecated && flag.RemoveInVersion == "" {
+			t.Errorf("flag %q is deprecated but has no RemoveInVersion", flag.Name)
+		}
+	}
+}
@@ -0,0 +1,100 @@
+// Package memory is a single-process Manager implementation, useful for
+// standalone Grafana instances and for tests that don't want to stand up
+// Redis or a SQL database.
+package memory
+
+import (
+	"context"
+	"sync"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+func init() {
+	leader.Register("memory", func(cfg leader.Config) (leader.Manager, error) {
+		return NewManager(), nil
+	})
+}
+
+type entry struct {
+	nodeID       string
+	leadershipID string
+	expiresAt    time.Time
+}
+
+// Manager keeps leadership state in an in-memory map. It is only meaningful
+// within a single process: running more than one Grafana instance against
+// separate Managers gives each instance its own view of who the leader is.
+type Manager struct {
+	mu      sync.Mutex
+	entries map[string]entry
+}
+
+// NewManager creates an in-memory Manager.
+func NewManager() *Manager {
+	return &Manager{
+		entries: make(map[string]entry),
+	}
+}
+
+func (m *Manager) GetOrCreateLeader(ctx context.Context, channel string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	now := time.Now()
+	e, ok := m.entries[channel]
+	if ok && e.expiresAt.After(now) {
+		return e.nodeID, e.leadershipID, nil
+	}
+
+	e = entry{
+		nodeID:       currentNodeID,
+		leadershipID: newLeadershipID,
+		expiresAt:    now.Add(leader.LeadershipEntryTTLSeconds * time.Second),
+	}
+	m.entries[channel] = e
+	return e.nodeID, e.leadershipID, nil
+}
+
+func (m *Manager) GetLeader(ctx context.Context, channel string) (bool, string, string, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[channel]
+	if !ok || !e.expiresAt.After(time.Now()) {
+		return false, "", "", nil
+	}
+	return true, e.nodeID, e.leadershipID, nil
+}
+
+func (m *Manager) RefreshLeader(ctx context.Context, channel string, currentLeadershipID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[channel]
+	if !ok || e.leadershipID != currentLeadershipID {
+		return false, nil
+	}
+	e.expiresAt = time.Now().Add(leader.LeadershipEntryTTLSeconds * time.Second)
+	m.entries[channel] = e
+	return true, nil
+}
+
+func (m *Manager) CleanLeader(ctx context.Context, channel string, leadershipID string) (bool, error) {
+	m.mu.Lock()
+	defer m.mu.Unlock()
+
+	e, ok := m.entries[channel]
+	if !ok || e.leadershipID != leadershipID {
+		return false, nil
+	}
+	delete(m.entries, channel)
+	return true, nil
+}
+
+// HealthCheck always reports healthy: an in-memory backend has no
+// replication topology to degrade.
+func (m *Manager) HealthCheck(ctx context.Context) (leader.HealthReport, error) {
+	return leader.HealthReport{State: leader.HealthHealthy}, nil
+}
@@ -0,0 +1,14 @@
+package memory
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+	"github.com/grafana/grafana/pkg/services/live/leader/leadertest"
+)
+
+func TestManager_Conformance(t *testing.T) {
+	leadertest.RunConformanceSuite(t, func() leader.Manager {
+		return NewManager()
+	})
+}
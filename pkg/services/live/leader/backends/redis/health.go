@@ -0,0 +1,53 @@
+package redis
+
+import (
+	"strconv"
+	"strings"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+// parseReplicationInfo extracts the fields leader election cares about out of
+// the response to a Redis `INFO replication` command.
+func parseReplicationInfo(info string, addr string) leader.NodeHealth {
+	fields := map[string]string{}
+	for _, line := range strings.Split(info, "\r\n") {
+		line = strings.TrimSpace(line)
+		if line == "" || strings.HasPrefix(line, "#") {
+			continue
+		}
+		parts := strings.SplitN(line, ":", 2)
+		if len(parts) != 2 {
+			continue
+		}
+		fields[parts[0]] = parts[1]
+	}
+
+	node := leader.NodeHealth{
+		Addr:             addr,
+		Role:             fields["role"],
+		MasterLinkStatus: fields["master_link_status"],
+	}
+	if offset, err := strconv.ParseInt(fields["slave_repl_offset"], 10, 64); err == nil {
+		node.ReplOffset = offset
+	} else if offset, err := strconv.ParseInt(fields["master_repl_offset"], 10, 64); err == nil {
+		node.ReplOffset = offset
+	}
+	return node
+}
+
+// stateForNode derives a HealthState from a single node report, used by
+// Manager which has no sentinel topology to reason about.
+func stateForNode(node leader.NodeHealth) leader.HealthState {
+	switch node.Role {
+	case "master":
+		return leader.HealthHealthy
+	case "slave":
+		if node.MasterLinkStatus == "up" {
+			return leader.HealthHealthy
+		}
+		return leader.HealthUnhealthy
+	default:
+		return leader.HealthDegraded
+	}
+}
@@ -0,0 +1,165 @@
+// Package redis is the original Manager implementation, backed by a single
+// Redis instance (or any redis.Client pointed at a master, e.g. via a
+// failover-aware UniversalClient). See the sibling memory and sql packages
+// for engines that don't require Redis.
+package redis
+
+import (
+	"context"
+	"errors"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+func init() {
+	leader.Register("redis", func(cfg leader.Config) (leader.Manager, error) {
+		client := goredis.NewClient(&goredis.Options{
+			Addr:     cfg.Redis.Address,
+			Password: cfg.Redis.Password,
+			DB:       cfg.Redis.DB,
+		})
+		return NewManager(cfg.Prefix, client), nil
+	})
+}
+
+type Manager struct {
+	prefix              string
+	redisClient         *goredis.Client
+	getOrCreateScript   *goredis.Script
+	refreshScript       *goredis.Script
+	cleanScript         *goredis.Script
+	refuseWhenUnhealthy bool
+}
+
+// KEYS[1] - channel hash key
+// ARGV[1] - hash key expire seconds
+// ARGV[2] - current node ID
+// ARGV[3] - new leadership ID if key does not exist yet
+// Returns leader nodeID and current leadershipID.
+const getOrCreateScriptSource = `
+if redis.call('exists', KEYS[1]) ~= 0 then
+	return redis.call("hmget", KEYS[1], "n", "l")
+end
+redis.call("hmset", KEYS[1], "n", ARGV[2], "l", ARGV[3])
+redis.call("expire", KEYS[1], ARGV[1])
+-- TODO: can avoid Redis call.
+return redis.call("hmget", KEYS[1], "n", "l")
+`
+
+// KEYS[1] - channel hash key
+// ARGV[1] - hash key expire seconds
+// ARGV[2] - expected leadership ID
+// Returns 1 if the expiration was refreshed, 0 otherwise.
+const refreshLeaderScriptSource = `
+if redis.call('exists', KEYS[1]) ~= 0 then
+	if redis.call('hget', KEYS[1], "l") ~= ARGV[2] then
+		return 0
+	end
+	redis.call("expire", KEYS[1], ARGV[1])
+	return 1
+end
+return 0
+`
+
+// KEYS[1] - channel hash key
+// ARGV[1] - leadership ID
+// Cleans leadership.
+const cleanLeaderScriptSource = `
+if redis.call("hget", KEYS[1], "l") == ARGV[1] then
+    return redis.call("del", KEYS[1])
+else
+    return 0
+end
+`
+
+// NewManager creates a Manager backed by a plain *goredis.Client.
+func NewManager(prefix string, redisClient *goredis.Client) *Manager {
+	return &Manager{
+		prefix:            prefix,
+		redisClient:       redisClient,
+		getOrCreateScript: goredis.NewScript(getOrCreateScriptSource),
+		refreshScript:     goredis.NewScript(refreshLeaderScriptSource),
+		cleanScript:       goredis.NewScript(cleanLeaderScriptSource),
+	}
+}
+
+// SetRefuseWhenUnhealthy controls whether GetOrCreateLeader should refuse to
+// hand out leadership when HealthCheck reports the backend as HealthUnhealthy.
+// Off by default so existing single-node Redis deployments keep working
+// unchanged.
+func (m *Manager) SetRefuseWhenUnhealthy(refuse bool) {
+	m.refuseWhenUnhealthy = refuse
+}
+
+func (m *Manager) getPrefixedChannel(ch string) string {
+	return m.prefix + ch
+}
+
+func (m *Manager) GetOrCreateLeader(ctx context.Context, ch string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	if m.refuseWhenUnhealthy {
+		report, err := m.HealthCheck(ctx)
+		if err != nil {
+			return "", "", err
+		}
+		if report.State == leader.HealthUnhealthy {
+			return "", "", leader.ErrBackendUnhealthy
+		}
+	}
+	return m.doGetOrCreateLeader(ctx, ch, currentNodeID, newLeadershipID)
+}
+
+// doGetOrCreateLeader runs the get-or-create script without the health guard,
+// so callers that already decided the backend is healthy enough (e.g. the
+// sentinel Manager, which checks Sentinel rather than this node) don't pay
+// for a second, redundant health check.
+func (m *Manager) doGetOrCreateLeader(ctx context.Context, ch string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	result, err := m.getOrCreateScript.Eval(ctx, m.redisClient, []string{m.getPrefixedChannel(ch)}, leader.LeadershipEntryTTLSeconds, currentNodeID, newLeadershipID).StringSlice()
+	if err != nil {
+		return "", "", err
+	}
+	if len(result) != 2 {
+		return "", "", errors.New("malformed result")
+	}
+	return result[0], result[1], nil
+}
+
+func (m *Manager) GetLeader(ctx context.Context, ch string) (bool, string, string, error) {
+	result, err := m.redisClient.HMGet(ctx, m.getPrefixedChannel(ch), "n", "l").Result()
+	if err != nil {
+		return false, "", "", err
+	}
+	if len(result) != 2 {
+		return false, "", "", errors.New("malformed result")
+	}
+	if result[0] == nil {
+		return false, "", "", nil
+	}
+	return true, result[0].(string), result[1].(string), nil
+}
+
+func (m *Manager) RefreshLeader(ctx context.Context, ch string, currentLeadershipID string) (bool, error) {
+	return m.refreshScript.Eval(ctx, m.redisClient, []string{m.getPrefixedChannel(ch)}, leader.LeadershipEntryTTLSeconds, currentLeadershipID).Bool()
+}
+
+func (m *Manager) CleanLeader(ctx context.Context, ch string, leadershipID string) (bool, error) {
+	return m.cleanScript.Eval(ctx, m.redisClient, []string{m.getPrefixedChannel(ch)}, leadershipID).Bool()
+}
+
+// HealthCheck reports the health of the single Redis node backing this
+// manager. There is no sentinel topology to inspect here, so the report
+// always contains a single node parsed out of INFO replication. The
+// sentinel Manager in this package reports on every node it knows about
+// instead.
+func (m *Manager) HealthCheck(ctx context.Context) (leader.HealthReport, error) {
+	info, err := m.redisClient.Info(ctx, "replication").Result()
+	if err != nil {
+		return leader.HealthReport{}, err
+	}
+	node := parseReplicationInfo(info, m.redisClient.Options().Addr)
+	return leader.HealthReport{
+		State: stateForNode(node),
+		Nodes: []leader.NodeHealth{node},
+	}, nil
+}
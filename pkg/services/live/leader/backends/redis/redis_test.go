@@ -0,0 +1,27 @@
+package redis
+
+import (
+	"context"
+	"os"
+	"testing"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+	"github.com/grafana/grafana/pkg/services/live/leader/leadertest"
+)
+
+func TestManager_Conformance(t *testing.T) {
+	addr := os.Getenv("REDIS_TEST_ADDR")
+	if addr == "" {
+		t.Skip("REDIS_TEST_ADDR not set, skipping Redis-backed leader.Manager conformance test")
+	}
+
+	client := goredis.NewClient(&goredis.Options{Addr: addr})
+	t.Cleanup(func() { _ = client.Close() })
+
+	leadertest.RunConformanceSuite(t, func() leader.Manager {
+		_ = client.FlushDB(context.Background())
+		return NewManager("gf_live_leader_test.", client)
+	})
+}
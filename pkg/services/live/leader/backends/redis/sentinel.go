@@ -0,0 +1,243 @@
+package redis
+
+import (
+	"context"
+	"fmt"
+	"strings"
+	"time"
+
+	goredis "github.com/go-redis/redis/v8"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+func init() {
+	leader.Register("redis-sentinel", func(cfg leader.Config) (leader.Manager, error) {
+		if len(cfg.Sentinel.Addresses) == 0 {
+			return nil, fmt.Errorf("redis-sentinel: at least one sentinel address is required")
+		}
+		failoverClient := goredis.NewFailoverClient(&goredis.FailoverOptions{
+			MasterName:    cfg.Sentinel.MasterName,
+			SentinelAddrs: cfg.Sentinel.Addresses,
+		})
+		sentinelClient := goredis.NewSentinelClient(&goredis.Options{
+			Addr: cfg.Sentinel.Addresses[0],
+		})
+		return NewSentinelManager(cfg.Prefix, failoverClient, sentinelClient, cfg.Sentinel.MasterName), nil
+	})
+}
+
+// SentinelManager is a Manager backed by a Redis deployment managed by
+// Sentinel. It delegates the leadership scripts to a Manager built on top of
+// a failover-aware client - that runs against whatever node the client
+// currently resolves as master - and adds a HealthCheck that talks to
+// Sentinel directly so operators can tell a healthy topology from a
+// failing-over or split-brained one before trusting it.
+type SentinelManager struct {
+	redisManager *Manager
+
+	sentinelClient *goredis.SentinelClient
+	masterName     string
+
+	healthCheckRetries int
+	healthCheckBackoff time.Duration
+}
+
+// SentinelOption configures a SentinelManager.
+type SentinelOption func(*SentinelManager)
+
+// WithHealthCheckRetries sets how many times HealthCheck retries a failed
+// sentinel call before giving up. Defaults to 2.
+func WithHealthCheckRetries(retries int) SentinelOption {
+	return func(m *SentinelManager) {
+		m.healthCheckRetries = retries
+	}
+}
+
+// WithHealthCheckBackoff sets the base backoff duration between HealthCheck
+// retries. Defaults to 100ms and doubles on each retry.
+func WithHealthCheckBackoff(backoff time.Duration) SentinelOption {
+	return func(m *SentinelManager) {
+		m.healthCheckBackoff = backoff
+	}
+}
+
+// NewSentinelManager creates a Manager whose GetOrCreateLeader/GetLeader/
+// RefreshLeader/CleanLeader run against redisClient (expected to be a
+// failover-aware client pointed at the current master via NewFailoverClient)
+// while HealthCheck queries sentinelClient directly for the master/slaves
+// topology behind masterName.
+func NewSentinelManager(prefix string, redisClient *goredis.Client, sentinelClient *goredis.SentinelClient, masterName string, opts ...SentinelOption) *SentinelManager {
+	m := &SentinelManager{
+		redisManager:       NewManager(prefix, redisClient),
+		sentinelClient:     sentinelClient,
+		masterName:         masterName,
+		healthCheckRetries: 2,
+		healthCheckBackoff: 100 * time.Millisecond,
+	}
+	for _, opt := range opts {
+		opt(m)
+	}
+	return m
+}
+
+// SetRefuseWhenUnhealthy controls whether GetOrCreateLeader should refuse to
+// hand out leadership when HealthCheck reports the backend as HealthUnhealthy.
+func (m *SentinelManager) SetRefuseWhenUnhealthy(refuse bool) {
+	m.redisManager.refuseWhenUnhealthy = refuse
+}
+
+// GetOrCreateLeader delegates to the wrapped Manager, refusing the request
+// when HealthCheck reports the sentinel topology as unhealthy and
+// SetRefuseWhenUnhealthy was enabled.
+func (m *SentinelManager) GetOrCreateLeader(ctx context.Context, channel string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	if m.redisManager.refuseWhenUnhealthy {
+		report, err := m.HealthCheck(ctx)
+		if err != nil {
+			return "", "", fmt.Errorf("leader backend health check failed: %w", err)
+		}
+		if report.State == leader.HealthUnhealthy {
+			return "", "", fmt.Errorf("%w: %s", leader.ErrBackendUnhealthy, report.Message)
+		}
+	}
+	return m.redisManager.doGetOrCreateLeader(ctx, channel, currentNodeID, newLeadershipID)
+}
+
+// GetLeader delegates to the wrapped Manager.
+func (m *SentinelManager) GetLeader(ctx context.Context, channel string) (bool, string, string, error) {
+	return m.redisManager.GetLeader(ctx, channel)
+}
+
+// RefreshLeader delegates to the wrapped Manager.
+func (m *SentinelManager) RefreshLeader(ctx context.Context, channel string, currentLeadershipID string) (bool, error) {
+	return m.redisManager.RefreshLeader(ctx, channel, currentLeadershipID)
+}
+
+// CleanLeader delegates to the wrapped Manager.
+func (m *SentinelManager) CleanLeader(ctx context.Context, channel string, leadershipID string) (bool, error) {
+	return m.redisManager.CleanLeader(ctx, channel, leadershipID)
+}
+
+// HealthCheck queries Sentinel for the master and slaves monitoring
+// masterName and reports each node's role, address, replication link status
+// and the overall verdict. Transient sentinel errors are retried with
+// exponential backoff before being returned.
+func (m *SentinelManager) HealthCheck(ctx context.Context) (leader.HealthReport, error) {
+	var report leader.HealthReport
+	var err error
+
+	backoff := m.healthCheckBackoff
+	for attempt := 0; attempt <= m.healthCheckRetries; attempt++ {
+		report, err = m.healthCheckOnce(ctx)
+		if err == nil {
+			return report, nil
+		}
+		if attempt == m.healthCheckRetries {
+			break
+		}
+		select {
+		case <-ctx.Done():
+			return leader.HealthReport{}, ctx.Err()
+		case <-time.After(backoff):
+		}
+		backoff *= 2
+	}
+	return leader.HealthReport{}, fmt.Errorf("sentinel health check failed after %d attempts: %w", m.healthCheckRetries+1, err)
+}
+
+func (m *SentinelManager) healthCheckOnce(ctx context.Context) (leader.HealthReport, error) {
+	masterInfo, err := m.sentinelClient.Master(ctx, m.masterName).Result()
+	if err != nil {
+		return leader.HealthReport{}, fmt.Errorf("sentinel master: %w", err)
+	}
+	master := nodeFromSentinelMap(masterInfo)
+	master.Role = "master"
+	state := stateForSentinelNode(master)
+
+	nodes := []leader.NodeHealth{master}
+
+	slaves, err := m.sentinelClient.Slaves(ctx, m.masterName).Result()
+	if err != nil {
+		return leader.HealthReport{}, fmt.Errorf("sentinel slaves: %w", err)
+	}
+	for _, raw := range slaves {
+		flat, ok := raw.([]interface{})
+		if !ok {
+			continue
+		}
+		slave := nodeFromSentinelMap(fieldsFromFlatSlice(flat))
+		slave.Role = "slave"
+		state = leader.WorstState(state, stateForSentinelNode(slave))
+		nodes = append(nodes, slave)
+	}
+
+	numOtherSentinels := 0
+	if n, ok := masterInfo["num-other-sentinels"]; ok {
+		if v, err := parseIntSafe(n); err == nil {
+			numOtherSentinels = v
+		}
+	}
+	if numOtherSentinels == 0 {
+		state = leader.WorstState(state, leader.HealthDegraded)
+	}
+
+	message := ""
+	if state != leader.HealthHealthy {
+		message = fmt.Sprintf("master %s flags=%q, %d other sentinel(s) known", master.Addr, master.Flags, numOtherSentinels)
+	}
+
+	return leader.HealthReport{
+		State:             state,
+		Nodes:             nodes,
+		NumOtherSentinels: numOtherSentinels,
+		Message:           message,
+	}, nil
+}
+
+// fieldsFromFlatSlice turns the flat [key1, value1, key2, value2, ...] slice
+// the go-redis v8 SentinelClient returns for SENTINEL SLAVES into the same
+// map[string]string shape SentinelClient.Master gives us, so both can feed
+// nodeFromSentinelMap.
+func fieldsFromFlatSlice(raw []interface{}) map[string]string {
+	fields := map[string]string{}
+	for i := 0; i+1 < len(raw); i += 2 {
+		key, ok := raw[i].(string)
+		if !ok {
+			continue
+		}
+		value, _ := raw[i+1].(string)
+		fields[key] = value
+	}
+	return fields
+}
+
+func nodeFromSentinelMap(fields map[string]string) leader.NodeHealth {
+	node := leader.NodeHealth{
+		Addr:             fmt.Sprintf("%s:%s", fields["ip"], fields["port"]),
+		MasterLinkStatus: fields["master-link-status"],
+		Flags:            fields["flags"],
+	}
+	if offset, err := parseIntSafe(fields["slave-repl-offset"]); err == nil {
+		node.ReplOffset = int64(offset)
+	}
+	return node
+}
+
+func stateForSentinelNode(node leader.NodeHealth) leader.HealthState {
+	flags := strings.Split(node.Flags, ",")
+	for _, f := range flags {
+		if f == "s_down" || f == "o_down" || f == "disconnected" {
+			return leader.HealthUnhealthy
+		}
+	}
+	if node.Role == "slave" && node.MasterLinkStatus == "err" {
+		return leader.HealthUnhealthy
+	}
+	return leader.HealthHealthy
+}
+
+func parseIntSafe(s string) (int, error) {
+	var v int
+	_, err := fmt.Sscanf(s, "%d", &v)
+	return v, err
+}
@@ -0,0 +1,28 @@
+package redis
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestFieldsFromFlatSlice(t *testing.T) {
+	raw := []interface{}{"ip", "10.0.0.2", "port", "6380", "flags", "slave", "master-link-status", "up"}
+
+	fields := fieldsFromFlatSlice(raw)
+
+	require.Equal(t, map[string]string{
+		"ip":                 "10.0.0.2",
+		"port":               "6380",
+		"flags":              "slave",
+		"master-link-status": "up",
+	}, fields)
+}
+
+func TestFieldsFromFlatSlice_OddLengthIgnoresTrailingKey(t *testing.T) {
+	raw := []interface{}{"ip", "10.0.0.2", "port"}
+
+	fields := fieldsFromFlatSlice(raw)
+
+	require.Equal(t, map[string]string{"ip": "10.0.0.2"}, fields)
+}
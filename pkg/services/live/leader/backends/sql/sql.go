@@ -0,0 +1,164 @@
+// Package sql is a Manager implementation backed by a SQL table, for
+// operators who don't want to run Redis just for Grafana Live HA. It works
+// against either Postgres or MySQL through the shared sqlstore.SQLStore, and
+// uses `SELECT ... FOR UPDATE` plus an expires_at column to give the same
+// GetOrCreate/Refresh/Clean semantics as the Redis Lua scripts, atomically.
+package sql
+
+import (
+	"context"
+	"time"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func init() {
+	register := func(cfg leader.Config) (leader.Manager, error) {
+		return NewManager(cfg.Prefix, cfg.SQL.SQLStore), nil
+	}
+	leader.Register("postgres", register)
+	leader.Register("mysql", register)
+}
+
+type channelLeaderRow struct {
+	Channel      string `xorm:"pk 'channel'"`
+	NodeID       string `xorm:"'node_id'"`
+	LeadershipID string `xorm:"'leadership_id'"`
+	ExpiresAt    int64  `xorm:"'expires_at'"`
+}
+
+func (channelLeaderRow) TableName() string {
+	return "live_channel_leader"
+}
+
+// Manager stores leadership state in the live_channel_leader table.
+type Manager struct {
+	prefix   string
+	sqlStore *sqlstore.SQLStore
+}
+
+// NewManager creates a SQL-backed Manager. prefix is prepended to channel
+// names before they're used as the table's primary key, mirroring the Redis
+// backend's key prefixing.
+func NewManager(prefix string, sqlStore *sqlstore.SQLStore) *Manager {
+	return &Manager{
+		prefix:   prefix,
+		sqlStore: sqlStore,
+	}
+}
+
+func (m *Manager) prefixedChannel(ch string) string {
+	return m.prefix + ch
+}
+
+func (m *Manager) GetOrCreateLeader(ctx context.Context, channel string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	var nodeID, leadershipID string
+	err := m.sqlStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		ch := m.prefixedChannel(channel)
+		now := time.Now().Unix()
+
+		var existing channelLeaderRow
+		has, err := sess.Table(channelLeaderRow{}).Where("channel = ?", ch).ForUpdate().Get(&existing)
+		if err != nil {
+			return err
+		}
+		if has && existing.ExpiresAt > now {
+			nodeID, leadershipID = existing.NodeID, existing.LeadershipID
+			return nil
+		}
+
+		row := channelLeaderRow{
+			Channel:      ch,
+			NodeID:       currentNodeID,
+			LeadershipID: newLeadershipID,
+			ExpiresAt:    now + leader.LeadershipEntryTTLSeconds,
+		}
+		if has {
+			_, err = sess.Table(channelLeaderRow{}).Where("channel = ?", ch).Update(&row)
+		} else {
+			_, err = sess.Insert(&row)
+		}
+		if err != nil {
+			return err
+		}
+		nodeID, leadershipID = row.NodeID, row.LeadershipID
+		return nil
+	})
+	if err != nil {
+		return "", "", err
+	}
+	return nodeID, leadershipID, nil
+}
+
+func (m *Manager) GetLeader(ctx context.Context, channel string) (bool, string, string, error) {
+	var row channelLeaderRow
+	var has bool
+	err := m.sqlStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var err error
+		has, err = sess.Table(channelLeaderRow{}).Where("channel = ? AND expires_at > ?", m.prefixedChannel(channel), time.Now().Unix()).Get(&row)
+		return err
+	})
+	if err != nil {
+		return false, "", "", err
+	}
+	if !has {
+		return false, "", "", nil
+	}
+	return true, row.NodeID, row.LeadershipID, nil
+}
+
+func (m *Manager) RefreshLeader(ctx context.Context, channel string, currentLeadershipID string) (bool, error) {
+	var refreshed bool
+	err := m.sqlStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		now := time.Now().Unix()
+		affected, err := sess.Exec(
+			"UPDATE live_channel_leader SET expires_at = ? WHERE channel = ? AND leadership_id = ? AND expires_at > ?",
+			now+leader.LeadershipEntryTTLSeconds, m.prefixedChannel(channel), currentLeadershipID, now,
+		)
+		if err != nil {
+			return err
+		}
+		n, err := affected.RowsAffected()
+		if err != nil {
+			return err
+		}
+		refreshed = n > 0
+		return nil
+	})
+	return refreshed, err
+}
+
+func (m *Manager) CleanLeader(ctx context.Context, channel string, leadershipID string) (bool, error) {
+	var cleaned bool
+	err := m.sqlStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		affected, err := sess.Exec(
+			"DELETE FROM live_channel_leader WHERE channel = ? AND leadership_id = ? AND expires_at > ?",
+			m.prefixedChannel(channel), leadershipID, time.Now().Unix(),
+		)
+		if err != nil {
+			return err
+		}
+		n, err := affected.RowsAffected()
+		if err != nil {
+			return err
+		}
+		cleaned = n > 0
+		return nil
+	})
+	return cleaned, err
+}
+
+// HealthCheck reports healthy whenever the table is reachable: there is no
+// replication topology to inspect, only connectivity to the configured
+// database.
+func (m *Manager) HealthCheck(ctx context.Context) (leader.HealthReport, error) {
+	err := m.sqlStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		_, err := sess.Exec("SELECT 1")
+		return err
+	})
+	if err != nil {
+		return leader.HealthReport{State: leader.HealthUnhealthy, Message: err.Error()}, nil
+	}
+	return leader.HealthReport{State: leader.HealthHealthy}, nil
+}
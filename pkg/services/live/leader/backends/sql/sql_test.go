@@ -0,0 +1,16 @@
+package sql
+
+import (
+	"testing"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+	"github.com/grafana/grafana/pkg/services/live/leader/leadertest"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+func TestManager_Conformance(t *testing.T) {
+	store := sqlstore.InitTestDB(t)
+	leadertest.RunConformanceSuite(t, func() leader.Manager {
+		return NewManager("gf_live_leader_test.", store)
+	})
+}
@@ -0,0 +1,61 @@
+package leader
+
+// HealthState is the overall verdict for a leader-election backend.
+type HealthState string
+
+const (
+	// HealthHealthy means the backend topology looks fully replicated and
+	// consistent, e.g. a master with all slaves reporting an "up" link.
+	HealthHealthy HealthState = "healthy"
+	// HealthDegraded means the backend is usable but not fully healthy, e.g.
+	// a slave is syncing or a sentinel is missing.
+	HealthDegraded HealthState = "degraded"
+	// HealthUnhealthy means the backend should not be trusted for leader
+	// election right now, e.g. the master link is down or no master is known.
+	HealthUnhealthy HealthState = "unhealthy"
+)
+
+// NodeHealth describes one backend node as seen by a Manager's HealthCheck.
+// Backends that have no notion of multiple nodes (e.g. the in-memory or SQL
+// backends) report no nodes at all.
+type NodeHealth struct {
+	// Addr identifies the node, e.g. host:port for Redis.
+	Addr string
+	// Role is "master"/"slave" for replicated backends, empty otherwise.
+	Role string
+	// MasterLinkStatus is the slave's link to its master as the backend
+	// reports it: "up" or "down" from INFO replication, "ok" or "err" from
+	// Sentinel. Empty for masters.
+	MasterLinkStatus string
+	// ReplOffset is the replication offset reported by the node, if any.
+	ReplOffset int64
+	// Flags are the raw Sentinel flags for this node, e.g. "master",
+	// "s_down", "o_down", "slave". Empty for backends not managed by Sentinel.
+	Flags string
+}
+
+// HealthReport is the result of Manager.HealthCheck.
+type HealthReport struct {
+	// State is the overall verdict across all known nodes.
+	State HealthState
+	// Nodes lists every node the backend knows about.
+	Nodes []NodeHealth
+	// NumOtherSentinels is the number of other sentinels the queried
+	// sentinel is aware of monitoring the same master. Zero when the backend
+	// is not sentinel-managed.
+	NumOtherSentinels int
+	// Message explains the verdict, e.g. which node caused a degraded or
+	// unhealthy state. Empty when State is HealthHealthy.
+	Message string
+}
+
+// WorstState returns the least healthy of two states, where
+// unhealthy < degraded < healthy. Backends combine per-node verdicts into an
+// overall HealthReport.State with this.
+func WorstState(a, b HealthState) HealthState {
+	rank := map[HealthState]int{HealthHealthy: 2, HealthDegraded: 1, HealthUnhealthy: 0}
+	if rank[b] < rank[a] {
+		return b
+	}
+	return a
+}
@@ -0,0 +1,45 @@
+package leader
+
+import (
+	"encoding/json"
+	"net/http"
+
+	"github.com/grafana/grafana/pkg/api/routing"
+	"github.com/grafana/grafana/pkg/middleware"
+)
+
+// HealthHandler exposes a Manager's HealthCheck result as JSON so operators
+// can verify the leader-election backend is trustworthy before relying on
+// the Live cluster, e.g. wired up as an admin-only route such as
+// /api/admin/live-ha/health.
+func HealthHandler(m Manager) http.HandlerFunc {
+	return func(w http.ResponseWriter, r *http.Request) {
+		report, err := m.HealthCheck(r.Context())
+		if err != nil {
+			w.Header().Set("Content-Type", "application/json")
+			w.WriteHeader(http.StatusServiceUnavailable)
+			_ = json.NewEncoder(w).Encode(map[string]string{"error": err.Error()})
+			return
+		}
+
+		status := http.StatusOK
+		if report.State == HealthUnhealthy {
+			status = http.StatusServiceUnavailable
+		}
+
+		w.Header().Set("Content-Type", "application/json")
+		w.WriteHeader(status)
+		_ = json.NewEncoder(w).Encode(report)
+	}
+}
+
+// RegisterHealthRoute mounts HealthHandler at /api/admin/live-ha/health,
+// restricted to Grafana admins. Call this once during HTTP server route
+// registration with the Manager built by NewFromSettingCfg, e.g.:
+//
+//	leader.RegisterHealthRoute(hs.RouteRegister, haManager)
+func RegisterHealthRoute(rr routing.RouteRegister, m Manager) {
+	rr.Group("/api/admin/live-ha", func(adminRoute routing.RouteRegister) {
+		adminRoute.Get("/health", HealthHandler(m))
+	}, middleware.ReqGrafanaAdmin)
+}
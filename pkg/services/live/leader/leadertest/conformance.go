@@ -0,0 +1,112 @@
+// Package leadertest holds shared test scenarios that every leader.Manager
+// backend must satisfy, so each backend's own test file can just plug its
+// constructor in and get the same coverage (contention, expiry,
+// refresh-by-wrong-id, clean-by-wrong-id) as the rest.
+package leadertest
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+// RunConformanceSuite runs the shared Manager scenarios against newManager(),
+// which must return a fresh, empty Manager each time it's called.
+func RunConformanceSuite(t *testing.T, newManager func() leader.Manager) {
+	t.Helper()
+
+	t.Run("contention: second caller gets the first leader back", func(t *testing.T) {
+		m := newManager()
+		ctx := context.Background()
+
+		nodeID, leadershipID, err := m.GetOrCreateLeader(ctx, "ch1", "node-a", "lease-a")
+		require.NoError(t, err)
+		require.Equal(t, "node-a", nodeID)
+		require.Equal(t, "lease-a", leadershipID)
+
+		nodeID, leadershipID, err = m.GetOrCreateLeader(ctx, "ch1", "node-b", "lease-b")
+		require.NoError(t, err)
+		require.Equal(t, "node-a", nodeID)
+		require.Equal(t, "lease-a", leadershipID)
+	})
+
+	t.Run("refresh by wrong leadership id fails", func(t *testing.T) {
+		m := newManager()
+		ctx := context.Background()
+
+		_, leadershipID, err := m.GetOrCreateLeader(ctx, "ch2", "node-a", "lease-a")
+		require.NoError(t, err)
+
+		ok, err := m.RefreshLeader(ctx, "ch2", "not-"+leadershipID)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		ok, err = m.RefreshLeader(ctx, "ch2", leadershipID)
+		require.NoError(t, err)
+		require.True(t, ok)
+	})
+
+	t.Run("clean by wrong leadership id fails, leader stays", func(t *testing.T) {
+		m := newManager()
+		ctx := context.Background()
+
+		_, leadershipID, err := m.GetOrCreateLeader(ctx, "ch3", "node-a", "lease-a")
+		require.NoError(t, err)
+
+		ok, err := m.CleanLeader(ctx, "ch3", "not-"+leadershipID)
+		require.NoError(t, err)
+		require.False(t, ok)
+
+		has, _, _, err := m.GetLeader(ctx, "ch3")
+		require.NoError(t, err)
+		require.True(t, has)
+
+		ok, err = m.CleanLeader(ctx, "ch3", leadershipID)
+		require.NoError(t, err)
+		require.True(t, ok)
+
+		has, _, _, err = m.GetLeader(ctx, "ch3")
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("expiry: a stale leader can be reclaimed", func(t *testing.T) {
+		if testing.Short() {
+			t.Skip("waits out leader.LeadershipEntryTTLSeconds, skipped in -short mode")
+		}
+		m := newManager()
+		ctx := context.Background()
+
+		_, _, err := m.GetOrCreateLeader(ctx, "ch4", "node-a", "lease-a")
+		require.NoError(t, err)
+
+		time.Sleep(time.Duration(leader.LeadershipEntryTTLSeconds+1) * time.Second)
+
+		nodeID, leadershipID, err := m.GetOrCreateLeader(ctx, "ch4", "node-b", "lease-b")
+		require.NoError(t, err)
+		require.Equal(t, "node-b", nodeID)
+		require.Equal(t, "lease-b", leadershipID)
+	})
+
+	t.Run("get leader on unknown channel reports no leader", func(t *testing.T) {
+		m := newManager()
+		ctx := context.Background()
+
+		has, _, _, err := m.GetLeader(ctx, "unknown-channel")
+		require.NoError(t, err)
+		require.False(t, has)
+	})
+
+	t.Run("health check succeeds against a fresh backend", func(t *testing.T) {
+		m := newManager()
+		ctx := context.Background()
+
+		report, err := m.HealthCheck(ctx)
+		require.NoError(t, err)
+		require.NotEmpty(t, report.State)
+	})
+}
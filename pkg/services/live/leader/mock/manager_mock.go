@@ -0,0 +1,41 @@
+package mock
+
+import (
+	"context"
+
+	"github.com/stretchr/testify/mock"
+
+	"github.com/grafana/grafana/pkg/services/live/leader"
+)
+
+var _ leader.Manager = new(ManagerMock)
+
+// ManagerMock is a testify mock implementing leader.Manager.
+type ManagerMock struct {
+	mock.Mock
+}
+
+func (m *ManagerMock) GetOrCreateLeader(ctx context.Context, channel string, currentNodeID string, newLeadershipID string) (string, string, error) {
+	args := m.Called(ctx, channel, currentNodeID, newLeadershipID)
+	return args.String(0), args.String(1), args.Error(2)
+}
+
+func (m *ManagerMock) GetLeader(ctx context.Context, channel string) (bool, string, string, error) {
+	args := m.Called(ctx, channel)
+	return args.Bool(0), args.String(1), args.String(2), args.Error(3)
+}
+
+func (m *ManagerMock) RefreshLeader(ctx context.Context, channel string, currentLeadershipID string) (bool, error) {
+	args := m.Called(ctx, channel, currentLeadershipID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ManagerMock) CleanLeader(ctx context.Context, channel string, leadershipID string) (bool, error) {
+	args := m.Called(ctx, channel, leadershipID)
+	return args.Bool(0), args.Error(1)
+}
+
+func (m *ManagerMock) HealthCheck(ctx context.Context) (leader.HealthReport, error) {
+	args := m.Called(ctx)
+	return args.Get(0).(leader.HealthReport), args.Error(1)
+}
@@ -0,0 +1,101 @@
+package leader
+
+import (
+	"fmt"
+	"sync"
+
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// RedisConfig configures a plain (non-Sentinel) Redis backend.
+type RedisConfig struct {
+	Address  string
+	Password string
+	DB       int
+}
+
+// SentinelConfig configures a Sentinel-aware Redis backend.
+type SentinelConfig struct {
+	Addresses  []string
+	MasterName string
+}
+
+// SQLConfig configures a SQL-backed backend (Postgres or MySQL, selected by
+// Config.Engine).
+type SQLConfig struct {
+	SQLStore *sqlstore.SQLStore
+}
+
+// Config describes how to build a Manager, independent of which Backend
+// ends up constructing it. Operators select Engine via setting.Cfg (see
+// NewFromSettingCfg); everything else is only consulted by the matching
+// backend.
+type Config struct {
+	// Engine selects the registered Backend to use, e.g. "redis", "postgres",
+	// "mysql" or "memory".
+	Engine string
+	// Prefix is prepended to channel names when the backend needs a
+	// namespaced key (e.g. a Redis key or the SQL table's channel column).
+	Prefix string
+
+	Redis    RedisConfig
+	Sentinel SentinelConfig
+	SQL      SQLConfig
+
+	// RefuseWhenUnhealthy asks the backend, if it supports
+	// RefuseWhenUnhealthyConfigurer, to refuse GetOrCreateLeader calls while
+	// HealthCheck reports it unhealthy rather than silently handing out
+	// leadership on a potentially split-brained topology.
+	RefuseWhenUnhealthy bool
+}
+
+// Backend builds a Manager from a Config. Backends register themselves
+// under a unique name via Register, typically from an init() function in
+// their package.
+type Backend func(cfg Config) (Manager, error)
+
+// RefuseWhenUnhealthyConfigurer is implemented by backends whose
+// GetOrCreateLeader can be told to refuse handing out leadership while
+// HealthCheck reports them unhealthy, e.g. the redis and redis-sentinel
+// backends. New consults it to apply Config.RefuseWhenUnhealthy.
+type RefuseWhenUnhealthyConfigurer interface {
+	SetRefuseWhenUnhealthy(refuse bool)
+}
+
+var (
+	backendsMu sync.RWMutex
+	backends   = map[string]Backend{}
+)
+
+// Register makes a Backend available under name for use by New. It panics if
+// called twice with the same name, analogous to how database/sql drivers
+// register themselves.
+func Register(name string, backend Backend) {
+	backendsMu.Lock()
+	defer backendsMu.Unlock()
+	if _, exists := backends[name]; exists {
+		panic("leader: Register called twice for backend " + name)
+	}
+	backends[name] = backend
+}
+
+// New builds a Manager using the Backend registered under cfg.Engine.
+func New(cfg Config) (Manager, error) {
+	backendsMu.RLock()
+	backend, ok := backends[cfg.Engine]
+	backendsMu.RUnlock()
+	if !ok {
+		return nil, fmt.Errorf("%w: %q", ErrNotConfigured, cfg.Engine)
+	}
+
+	m, err := backend(cfg)
+	if err != nil {
+		return nil, err
+	}
+	if cfg.RefuseWhenUnhealthy {
+		if configurer, ok := m.(RefuseWhenUnhealthyConfigurer); ok {
+			configurer.SetRefuseWhenUnhealthy(true)
+		}
+	}
+	return m, nil
+}
@@ -0,0 +1,38 @@
+package leader
+
+import (
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+	"github.com/grafana/grafana/pkg/setting"
+)
+
+// NewFromSettingCfg builds a Manager for Grafana Live HA using the engine
+// selected by the `ha_engine` key of the `[live]` section
+// (redis|redis-sentinel|postgres|mysql|memory,
+// defaulting to memory so a bare Grafana instance never needs Redis).
+// Import the backend package(s) you intend to use for side-effecting
+// registration, e.g.:
+//
+//	import _ "github.com/grafana/grafana/pkg/services/live/leader/backends/redis"
+func NewFromSettingCfg(cfg *setting.Cfg, sqlStore *sqlstore.SQLStore) (Manager, error) {
+	engine := cfg.LiveHAEngine
+	if engine == "" {
+		engine = "memory"
+	}
+
+	return New(Config{
+		Engine: engine,
+		Prefix: "gf_live_leader.",
+		Redis: RedisConfig{
+			Address:  cfg.LiveHAEngineAddress,
+			Password: cfg.LiveHAEnginePassword,
+		},
+		Sentinel: SentinelConfig{
+			Addresses:  cfg.LiveHASentinelAddresses,
+			MasterName: cfg.LiveHASentinelMasterName,
+		},
+		SQL: SQLConfig{
+			SQLStore: sqlStore,
+		},
+		RefuseWhenUnhealthy: cfg.LiveHARefuseWhenUnhealthy,
+	})
+}
@@ -0,0 +1,22 @@
+// Package ngalert is Grafana's unified alerting service.
+package ngalert
+
+import (
+	"context"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/store"
+)
+
+// AlertNG is the ngalert service. Only the fields/methods touched by
+// packages in this checkout are declared here; the rest of the alerting
+// service lives on the same struct.
+type AlertNG struct {
+	store store.DBstore
+}
+
+// Run starts AlertNG's background work and blocks until ctx is done.
+func (ng *AlertNG) Run(ctx context.Context) error {
+	ng.store.ScheduleProvenanceGC(ctx, store.DefaultProvenanceGCInterval)
+	<-ctx.Done()
+	return ctx.Err()
+}
@@ -0,0 +1,102 @@
+package store
+
+import (
+	"context"
+	"math/rand"
+	"time"
+
+	"github.com/prometheus/client_golang/prometheus"
+	"github.com/prometheus/client_golang/prometheus/promauto"
+
+	"github.com/grafana/grafana/pkg/infra/log"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// DefaultProvenanceGCInterval is how often RunProvenanceGC sweeps stale
+// provenance records when the operator hasn't overridden it.
+const DefaultProvenanceGCInterval = 24 * time.Hour
+
+var (
+	provenanceGCDeletedTotal = promauto.NewCounter(prometheus.CounterOpts{
+		Namespace: "grafana",
+		Subsystem: "alerting",
+		Name:      "provenance_gc_deleted_total",
+		Help:      "Number of stale provenance rows removed by the periodic GC.",
+	})
+	provenanceRows = promauto.NewGaugeVec(prometheus.GaugeOpts{
+		Namespace: "grafana",
+		Subsystem: "alerting",
+		Name:      "provenance_rows",
+		Help:      "Number of provenance rows currently stored, by record type.",
+	}, []string{"record_type"})
+)
+
+// ScheduleProvenanceGC starts RunProvenanceGC in a background goroutine,
+// using NewProvisioningResolver so every provisionable resource type is
+// covered, and stops it once ctx is done. Call this once from
+// AlertNG.Run; interval defaults to DefaultProvenanceGCInterval when zero
+// or negative.
+func (st DBstore) ScheduleProvenanceGC(ctx context.Context, interval time.Duration) {
+	go st.RunProvenanceGC(ctx, NewProvisioningResolver(st), interval)
+}
+
+// RunProvenanceGC calls GCProvenances on interval (jittered by up to 10%)
+// until ctx is done, logging and recording metrics for each sweep. interval
+// defaults to DefaultProvenanceGCInterval when zero or negative.
+func (st DBstore) RunProvenanceGC(ctx context.Context, resolver Resolver, interval time.Duration) {
+	if interval <= 0 {
+		interval = DefaultProvenanceGCInterval
+	}
+	logger := log.New("ngalert.provisioning.gc")
+
+	for {
+		jitter := time.Duration(rand.Int63n(int64(interval) / 10))
+		select {
+		case <-ctx.Done():
+			return
+		case <-time.After(interval + jitter):
+		}
+
+		deleted, err := st.GCProvenances(ctx, resolver)
+		if err != nil {
+			logger.Warn("failed to garbage collect stale provenance records", "error", err)
+			continue
+		}
+		if deleted > 0 {
+			provenanceGCDeletedTotal.Add(float64(deleted))
+			logger.Info("garbage collected stale provenance records", "deleted", deleted)
+		}
+
+		st.reportProvenanceRowCounts(ctx, logger)
+	}
+}
+
+func (st DBstore) reportProvenanceRowCounts(ctx context.Context, logger log.Logger) {
+	counts, err := st.countProvenanceRowsByType(ctx)
+	if err != nil {
+		logger.Warn("failed to count provenance rows", "error", err)
+		return
+	}
+	for recordType, count := range counts {
+		provenanceRows.WithLabelValues(recordType).Set(float64(count))
+	}
+}
+
+func (st DBstore) countProvenanceRowsByType(ctx context.Context) (map[string]int64, error) {
+	counts := map[string]int64{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		type row struct {
+			RecordType string
+			Count      int64
+		}
+		var rows []row
+		if err := sess.Table(provenanceRecord{}).Select("record_type, count(*) as count").GroupBy("record_type").Find(&rows); err != nil {
+			return err
+		}
+		for _, r := range rows {
+			counts[r.RecordType] = r.Count
+		}
+		return nil
+	})
+	return counts, err
+}
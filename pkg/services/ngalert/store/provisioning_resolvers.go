@@ -0,0 +1,103 @@
+package store
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+// Record types tracked by ProvisioningStore. These match the strings
+// returned by the models.Provisionable implementations for each resource.
+const (
+	alertRuleRecordType          = "alertRule"
+	contactPointRecordType       = "contactPoint"
+	notificationPolicyRecordType = "policy"
+	muteTimingRecordType         = "muteTimeInterval"
+	templateRecordType           = "template"
+)
+
+// notificationPolicyRecordKey is the fixed record key notification policies
+// are provisioned under: there is exactly one policy tree per org, so unlike
+// the other record types it isn't keyed by name.
+const notificationPolicyRecordKey = "policy"
+
+// NewProvisioningResolver returns the Resolver GCProvenances should use in
+// production. It knows about every provisionable resource type - alert
+// rules, contact points, notification policies, mute timings and templates -
+// and dispatches ExistingKeys to the right subsystem by recordType.
+func NewProvisioningResolver(store DBstore) Resolver {
+	return combinedResolver{
+		alertRules: alertRuleResolver{store: store},
+		amConfig:   alertmanagerConfigResolver{store: store},
+	}
+}
+
+type combinedResolver struct {
+	alertRules alertRuleResolver
+	amConfig   alertmanagerConfigResolver
+}
+
+func (r combinedResolver) ExistingKeys(ctx context.Context, orgID int64, recordType string) (map[string]struct{}, error) {
+	if recordType == alertRuleRecordType {
+		return r.alertRules.ExistingKeys(ctx, orgID, recordType)
+	}
+	return r.amConfig.ExistingKeys(ctx, orgID, recordType)
+}
+
+// alertRuleResolver resolves ExistingKeys for recordType "alertRule" by
+// listing the UIDs of every alert rule still defined in orgID.
+type alertRuleResolver struct {
+	store DBstore
+}
+
+func (r alertRuleResolver) ExistingKeys(ctx context.Context, orgID int64, recordType string) (map[string]struct{}, error) {
+	keys := map[string]struct{}{}
+	err := r.store.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var uids []string
+		if err := sess.Table("alert_rule").Where("org_id = ?", orgID).Cols("uid").Find(&uids); err != nil {
+			return fmt.Errorf("failed to list alert rule uids: %w", err)
+		}
+		for _, uid := range uids {
+			keys[uid] = struct{}{}
+		}
+		return nil
+	})
+	return keys, err
+}
+
+// alertmanagerConfigResolver resolves ExistingKeys for the provisionable
+// objects that live inside an org's Alertmanager configuration - contact
+// points, notification policies, mute timings and templates - by loading the
+// latest applied config and reading the names of each recordType out of it.
+type alertmanagerConfigResolver struct {
+	store DBstore
+}
+
+func (r alertmanagerConfigResolver) ExistingKeys(ctx context.Context, orgID int64, recordType string) (map[string]struct{}, error) {
+	cfg, err := r.store.GetLatestAlertmanagerConfiguration(ctx, orgID)
+	if err != nil {
+		return nil, fmt.Errorf("failed to load alertmanager configuration for org %d: %w", orgID, err)
+	}
+
+	keys := map[string]struct{}{}
+	switch recordType {
+	case contactPointRecordType:
+		for _, receiver := range cfg.AlertmanagerConfig.Receivers {
+			keys[receiver.Name] = struct{}{}
+		}
+	case muteTimingRecordType:
+		for _, mt := range cfg.AlertmanagerConfig.MuteTimeIntervals {
+			keys[mt.Name] = struct{}{}
+		}
+	case templateRecordType:
+		for name := range cfg.AlertmanagerConfig.Templates {
+			keys[name] = struct{}{}
+		}
+	case notificationPolicyRecordType:
+		keys[notificationPolicyRecordKey] = struct{}{}
+	default:
+		return nil, fmt.Errorf("alertmanagerConfigResolver: unknown record type %q", recordType)
+	}
+	return keys, nil
+}
@@ -14,22 +14,39 @@ type provenanceRecord struct {
 	RecordKey  string
 	RecordType string
 	Provenance models.Provenance
+	// Version is a concurrency token: SetProvenanceTransactional only
+	// updates a row whose Version still matches what it read, so two
+	// concurrent writers can't silently lose one of their updates.
+	Version int64
 }
 
 func (pr provenanceRecord) TableName() string {
 	return "provenance_type"
 }
 
+// Resolver reports which record keys currently exist for a given
+// provenance-tracked resource type, so GCProvenances can tell a live record
+// from a stale one left behind by a deleted alert rule, contact point, etc.
+type Resolver interface {
+	// ExistingKeys returns the set of record keys, for recordType in orgID,
+	// that still exist and whose provenance should therefore be kept.
+	ExistingKeys(ctx context.Context, orgID int64, recordType string) (map[string]struct{}, error)
+}
+
 // ProvisioningStore is a store of provisioning data for arbitrary objects.
 type ProvisioningStore interface {
 	GetProvenance(ctx context.Context, o models.Provisionable) (models.Provenance, error)
-	// TODO: API to query all provenances for a specific type?
+	GetProvenances(ctx context.Context, orgID int64, recordType string) (map[string]models.Provenance, error)
 	SetProvenance(ctx context.Context, o models.Provisionable, p models.Provenance) error
+	// GCProvenances deletes provenance rows whose record key is no longer
+	// known to resolver, batching deletes inside a single transaction, and
+	// returns how many rows were removed.
+	GCProvenances(ctx context.Context, resolver Resolver) (int, error)
 }
 
 type TransactionalProvisioningStore interface {
 	GetProvenance(ctx context.Context, o models.Provisionable) (models.Provenance, error)
-	// TODO: API to query all provenances for a specific type?
+	GetProvenances(ctx context.Context, orgID int64, recordType string) (map[string]models.Provenance, error)
 	SetProvenanceTransactional(o models.Provisionable, p models.Provenance, uow UnitOfWork) UnitOfWork
 }
 
@@ -57,6 +74,83 @@ func (st DBstore) GetProvenance(ctx context.Context, o models.Provisionable) (mo
 	return provenance, nil
 }
 
+// GetProvenances returns the provenance of every known record of recordType
+// in orgID, keyed by record key. Records with no provenance row set are not
+// included - callers should treat a missing key as models.ProvenanceNone,
+// same as GetProvenance does for a single record.
+func (st DBstore) GetProvenances(ctx context.Context, orgID int64, recordType string) (map[string]models.Provenance, error) {
+	result := map[string]models.Provenance{}
+	err := st.SQLStore.WithDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var records []provenanceRecord
+		filter := "record_type = ? AND org_id = ?"
+		if err := sess.Table(provenanceRecord{}).Where(filter, recordType, orgID).Find(&records); err != nil {
+			return fmt.Errorf("failed to query for provenance statuses: %w", err)
+		}
+		for _, record := range records {
+			result[record.RecordKey] = record.Provenance
+		}
+		return nil
+	})
+	if err != nil {
+		return nil, err
+	}
+	return result, nil
+}
+
+// GCProvenances deletes provenance rows whose record key resolver no longer
+// reports for the row's org and record type, batching deletes inside a single
+// transaction. It returns the total number of rows removed.
+func (st DBstore) GCProvenances(ctx context.Context, resolver Resolver) (int, error) {
+	deleted := 0
+	err := st.SQLStore.WithTransactionalDbSession(ctx, func(sess *sqlstore.DBSession) error {
+		var records []provenanceRecord
+		if err := sess.Table(provenanceRecord{}).Find(&records); err != nil {
+			return fmt.Errorf("failed to list provenance records for GC: %w", err)
+		}
+
+		type orgType struct {
+			orgID      int64
+			recordType string
+		}
+		existingByOrgType := map[orgType]map[string]struct{}{}
+
+		var staleIDs []int
+		for _, record := range records {
+			key := orgType{orgID: record.OrgID, recordType: record.RecordType}
+			existing, ok := existingByOrgType[key]
+			if !ok {
+				var err error
+				existing, err = resolver.ExistingKeys(ctx, key.orgID, key.recordType)
+				if err != nil {
+					return fmt.Errorf("failed to resolve existing keys for org %d type %s: %w", key.orgID, key.recordType, err)
+				}
+				existingByOrgType[key] = existing
+			}
+			if _, ok := existing[record.RecordKey]; !ok {
+				staleIDs = append(staleIDs, record.Id)
+			}
+		}
+
+		const batchSize = 100
+		for i := 0; i < len(staleIDs); i += batchSize {
+			end := i + batchSize
+			if end > len(staleIDs) {
+				end = len(staleIDs)
+			}
+			n, err := sess.Table(provenanceRecord{}).In("id", staleIDs[i:end]).Delete(provenanceRecord{})
+			if err != nil {
+				return fmt.Errorf("failed to delete stale provenance records: %w", err)
+			}
+			deleted += int(n)
+		}
+		return nil
+	})
+	if err != nil {
+		return 0, err
+	}
+	return deleted, nil
+}
+
 func (st DBstore) SetProvenance(ctx context.Context, o models.Provisionable, p models.Provenance) error {
 	xact := NewTransaction(st.SQLStore)
 	xact = st.SetProvenanceTransactional(o, p, xact)
@@ -69,25 +163,42 @@ func (st DBstore) SetProvenanceTransactional(o models.Provisionable, p models.Pr
 	orgID := o.ResourceOrgID()
 
 	uow = uow.Do(func(sess *sqlstore.DBSession) error {
-		// TODO: Need to make sure that writing a record where our concurrency key fails will also fail the whole transaction. That way, this gets rolled back too. can't just check that 0 updates happened inmemory. Check with jp. If not possible, we need our own concurrency key.
-		// TODO: Clean up stale provenance records periodically.
 		filter := "record_key = ? AND record_type = ? AND org_id = ?"
-		_, err := sess.Table(provenanceRecord{}).Where(filter, recordKey, recordType, orgID).Delete(provenanceRecord{})
 
+		var existing provenanceRecord
+		has, err := sess.Table(provenanceRecord{}).Where(filter, recordKey, recordType, orgID).Get(&existing)
 		if err != nil {
-			return fmt.Errorf("failed to delete pre-existing provisioning status: %w", err)
+			return fmt.Errorf("failed to query for existing provisioning status: %w", err)
 		}
 
-		record := provenanceRecord{
-			RecordKey:  recordKey,
-			RecordType: recordType,
-			Provenance: p,
-			OrgID:      orgID,
+		if !has {
+			record := provenanceRecord{
+				RecordKey:  recordKey,
+				RecordType: recordType,
+				Provenance: p,
+				OrgID:      orgID,
+				Version:    1,
+			}
+			if _, err := sess.Insert(record); err != nil {
+				return fmt.Errorf("failed to store provisioning status: %w", err)
+			}
+			return nil
 		}
 
-		if _, err := sess.Insert(record); err != nil {
+		// Only update the row if its Version still matches what we just
+		// read: if a concurrent writer updated it in between, this affects
+		// zero rows and we fail the whole transaction instead of silently
+		// losing one of the two writes.
+		n, err := sess.Table(provenanceRecord{}).
+			Where("id = ? AND version = ?", existing.Id, existing.Version).
+			Cols("provenance", "version").
+			Update(&provenanceRecord{Provenance: p, Version: existing.Version + 1})
+		if err != nil {
 			return fmt.Errorf("failed to store provisioning status: %w", err)
 		}
+		if n == 0 {
+			return fmt.Errorf("provisioning status for %s/%s in org %d was concurrently modified, retry", recordType, recordKey, orgID)
+		}
 
 		return nil
 	})
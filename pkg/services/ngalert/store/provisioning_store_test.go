@@ -0,0 +1,81 @@
+package store
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+
+	"github.com/grafana/grafana/pkg/services/ngalert/models"
+	"github.com/grafana/grafana/pkg/services/sqlstore"
+)
+
+type fakeProvisionable struct {
+	orgID      int64
+	recordType string
+	recordKey  string
+}
+
+func (f fakeProvisionable) ResourceType() string { return f.recordType }
+func (f fakeProvisionable) ResourceID() string   { return f.recordKey }
+func (f fakeProvisionable) ResourceOrgID() int64 { return f.orgID }
+
+func TestDBstore_GetProvenances(t *testing.T) {
+	st := DBstore{SQLStore: sqlstore.InitTestDB(t)}
+	ctx := context.Background()
+
+	ruleA := fakeProvisionable{orgID: 1, recordType: "alertRule", recordKey: "rule-a"}
+	ruleB := fakeProvisionable{orgID: 1, recordType: "alertRule", recordKey: "rule-b"}
+	otherOrgRule := fakeProvisionable{orgID: 2, recordType: "alertRule", recordKey: "rule-a"}
+
+	require.NoError(t, st.SetProvenance(ctx, ruleA, models.ProvenanceAPI))
+	require.NoError(t, st.SetProvenance(ctx, ruleB, models.ProvenanceFile))
+	require.NoError(t, st.SetProvenance(ctx, otherOrgRule, models.ProvenanceAPI))
+
+	provenances, err := st.GetProvenances(ctx, 1, "alertRule")
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.Provenance{
+		"rule-a": models.ProvenanceAPI,
+		"rule-b": models.ProvenanceFile,
+	}, provenances)
+}
+
+func TestDBstore_SetProvenanceTransactional_ConcurrentModificationConflict(t *testing.T) {
+	st := DBstore{SQLStore: sqlstore.InitTestDB(t)}
+	ctx := context.Background()
+	rule := fakeProvisionable{orgID: 1, recordType: "alertRule", recordKey: "rule-a"}
+
+	require.NoError(t, st.SetProvenance(ctx, rule, models.ProvenanceAPI))
+
+	xactA := st.SetProvenanceTransactional(rule, models.ProvenanceFile, NewTransaction(st.SQLStore))
+	xactB := st.SetProvenanceTransactional(rule, models.ProvenanceFile, NewTransaction(st.SQLStore))
+
+	require.NoError(t, xactA.Execute(ctx))
+	require.Error(t, xactB.Execute(ctx))
+}
+
+type staticResolver struct {
+	existing map[string]struct{}
+}
+
+func (r staticResolver) ExistingKeys(ctx context.Context, orgID int64, recordType string) (map[string]struct{}, error) {
+	return r.existing, nil
+}
+
+func TestDBstore_GCProvenances(t *testing.T) {
+	st := DBstore{SQLStore: sqlstore.InitTestDB(t)}
+	ctx := context.Background()
+
+	kept := fakeProvisionable{orgID: 1, recordType: "alertRule", recordKey: "kept"}
+	stale := fakeProvisionable{orgID: 1, recordType: "alertRule", recordKey: "stale"}
+	require.NoError(t, st.SetProvenance(ctx, kept, models.ProvenanceAPI))
+	require.NoError(t, st.SetProvenance(ctx, stale, models.ProvenanceAPI))
+
+	deleted, err := st.GCProvenances(ctx, staticResolver{existing: map[string]struct{}{"kept": {}}})
+	require.NoError(t, err)
+	require.Equal(t, 1, deleted)
+
+	provenances, err := st.GetProvenances(ctx, 1, "alertRule")
+	require.NoError(t, err)
+	require.Equal(t, map[string]models.Provenance{"kept": models.ProvenanceAPI}, provenances)
+}
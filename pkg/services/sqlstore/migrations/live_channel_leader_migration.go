@@ -0,0 +1,25 @@
+package migrations
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// addLiveChannelLeaderMigrations creates the live_channel_leader table used
+// by the sql leader-election backend
+// (pkg/services/live/leader/backends/sql) to store Grafana Live HA
+// leadership state when Redis isn't available. Called from AddMigrations
+// alongside the other add*Migrations functions.
+func addLiveChannelLeaderMigrations(mg *migrator.Migrator) {
+	table := migrator.Table{
+		Name: "live_channel_leader",
+		Columns: []*migrator.Column{
+			{Name: "channel", Type: migrator.DB_NVarchar, Length: 255, IsPrimaryKey: true},
+			{Name: "node_id", Type: migrator.DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "leadership_id", Type: migrator.DB_NVarchar, Length: 255, Nullable: false},
+			{Name: "expires_at", Type: migrator.DB_BigInt, Nullable: false},
+		},
+	}
+
+	mg.AddMigration("create live_channel_leader table", migrator.NewAddTableMigration(table))
+	mg.AddMigration("add index live_channel_leader.expires_at", migrator.NewAddIndexMigration(table, &migrator.Index{
+		Cols: []string{"expires_at"},
+	}))
+}
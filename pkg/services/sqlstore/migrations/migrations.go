@@ -0,0 +1,12 @@
+package migrations
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// AddMigrations registers every migration the migrator should run, in
+// order. Only the migrations touched by packages in this checkout are
+// registered here; the rest of Grafana's migration history runs from the
+// same function.
+func AddMigrations(mg *migrator.Migrator) {
+	addLiveChannelLeaderMigrations(mg)
+	addProvenanceTypeVersionMigrations(mg)
+}
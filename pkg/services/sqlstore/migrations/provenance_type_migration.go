@@ -0,0 +1,17 @@
+package migrations
+
+import "github.com/grafana/grafana/pkg/services/sqlstore/migrator"
+
+// addProvenanceTypeVersionMigrations adds the concurrency-token column and
+// lookup index that store.SetProvenanceTransactional and
+// store.GetProvenances rely on.
+func addProvenanceTypeVersionMigrations(mg *migrator.Migrator) {
+	table := migrator.Table{Name: "provenance_type"}
+
+	mg.AddMigration("add version column to provenance_type", migrator.NewAddColumnMigration(table, &migrator.Column{
+		Name: "version", Type: migrator.DB_BigInt, Nullable: false, Default: "0",
+	}))
+	mg.AddMigration("add index provenance_type.org_id_record_type", migrator.NewAddIndexMigration(table, &migrator.Index{
+		Cols: []string{"org_id", "record_type"},
+	}))
+}
@@ -0,0 +1,24 @@
+package setting
+
+import "strings"
+
+// readLiveHASettings reads the [live] section's ha_engine/ha_engine_address/
+// ha_engine_password/ha_sentinel_addresses/ha_sentinel_master_name/
+// ha_refuse_when_unhealthy keys into cfg. See
+// pkg/services/live/leader.NewFromSettingCfg, the consumer of these
+// settings.
+func (cfg *Cfg) readLiveHASettings() {
+	liveSection := cfg.Raw.Section("live")
+
+	cfg.LiveHAEngine = liveSection.Key("ha_engine").MustString("")
+	cfg.LiveHAEngineAddress = liveSection.Key("ha_engine_address").MustString("")
+	cfg.LiveHAEnginePassword = liveSection.Key("ha_engine_password").MustString("")
+	cfg.LiveHASentinelMasterName = liveSection.Key("ha_sentinel_master_name").MustString("")
+	cfg.LiveHARefuseWhenUnhealthy = liveSection.Key("ha_refuse_when_unhealthy").MustBool(false)
+
+	if addrs := liveSection.Key("ha_sentinel_addresses").MustString(""); addrs != "" {
+		for _, addr := range strings.Split(addrs, ",") {
+			cfg.LiveHASentinelAddresses = append(cfg.LiveHASentinelAddresses, strings.TrimSpace(addr))
+		}
+	}
+}
@@ -0,0 +1,35 @@
+// Package setting holds Grafana's runtime configuration, parsed out of the
+// .ini config files into a single Cfg.
+package setting
+
+import "gopkg.in/ini.v1"
+
+// Cfg holds Grafana's parsed configuration. Only the fields touched by
+// packages in this checkout are declared here; the rest of Grafana's
+// configuration lives on the same struct.
+type Cfg struct {
+	// Raw is the parsed .ini configuration Cfg was built from.
+	Raw *ini.File
+
+	// LiveHAEngine selects the leader-election backend Grafana Live HA uses,
+	// e.g. "redis", "redis-sentinel", "postgres", "mysql" or "memory". Empty
+	// defaults to "memory" so a bare Grafana instance never needs Redis.
+	LiveHAEngine string
+	// LiveHAEngineAddress is the address of the engine selected by
+	// LiveHAEngine, e.g. a Redis host:port. Unused by the memory engine.
+	LiveHAEngineAddress string
+	// LiveHAEnginePassword authenticates against LiveHAEngineAddress, if the
+	// engine requires it.
+	LiveHAEnginePassword string
+	// LiveHASentinelAddresses lists the Sentinel addresses to query when
+	// LiveHAEngine is "redis-sentinel".
+	LiveHASentinelAddresses []string
+	// LiveHASentinelMasterName is the master name Sentinel monitors, required
+	// when LiveHAEngine is "redis-sentinel".
+	LiveHASentinelMasterName string
+	// LiveHARefuseWhenUnhealthy makes the engine selected by LiveHAEngine
+	// refuse to hand out leadership while its HealthCheck reports it
+	// unhealthy, instead of risking a split-brained topology. Off by default
+	// so existing deployments keep working unchanged.
+	LiveHARefuseWhenUnhealthy bool
+}